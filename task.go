@@ -1,104 +1,231 @@
-package grace
-
-import (
-	"errors"
-	"fmt"
-	"reflect"
-)
-
-// Task is a reflected task that will run.
-// Name: the Name of this task
-// Fn: the internal function that will run.
-// ReturnValueTypes: types of the return values. IMPORTANT: this will not include the error return
-type Task struct {
-	Name             string
-	Fn               reflect.Value
-	ReturnValueTypes []reflect.Type
-	hasErrorOut      bool
-	Cleanup          func() error
-}
-
-var (
-	emptyTypes = make([]reflect.Type, 0)
-)
-
-type TaskConfig struct {
-	Name    string
-	Cleanup func() error
-	Fn      interface{}
-}
-
-// NewTask creates new Task instance
-func NewTask(config *TaskConfig) (*Task, error) {
-	if config.Fn == nil {
-		return nil, fmt.Errorf("failed to create task: '%s': Fn cannot be nil", config.Name)
-	}
-
-	fnType := reflect.TypeOf(config.Fn)
-
-	var err error
-
-	if err = isFunc(fnType); err != nil {
-		return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
-	}
-
-	hasErrOut := hasErrorOut(fnType)
-
-	return &Task{
-		Fn:               reflect.ValueOf(config.Fn),
-		Name:             config.Name,
-		Cleanup:          config.Cleanup,
-		hasErrorOut:      hasErrOut,
-		ReturnValueTypes: getReturnValueTypes(fnType, hasErrOut),
-	}, nil
-}
-
-// Run executes a function with given params
-// the result of the Run will exclude the last error output if its Fn has an error out.
-func (t *Task) Run(params []reflect.Value) ([]reflect.Value, error) {
-	ret := t.Fn.Call(params)
-
-	if t.hasErrorOut { // has error out
-		if err, ok := ret[len(ret)-1].Interface().(error); ok && err != nil {
-			return t.doCleanup(nil, err)
-		} else {
-			return t.doCleanup(ret[:len(ret)-1], nil)
-		}
-	}
-
-	// return results as-is
-	return t.doCleanup(ret, nil)
-}
-
-func (t *Task) doCleanup(v []reflect.Value, err error) ([]reflect.Value, error) {
-	if t.Cleanup != nil {
-		if cuErr := t.Cleanup(); cuErr != nil {
-			return v, errors.Join(cuErr, err)
-		}
-	}
-	return v, err
-}
-
-func hasErrorOut(fn reflect.Type) bool {
-	return fn.NumOut() > 0 && fn.Out(fn.NumOut()-1).AssignableTo(reflect.TypeFor[error]())
-}
-
-// getReturnValueTypes returns values-only types that excludes the last error type if exists
-func getReturnValueTypes(fn reflect.Type, hasErrorOut bool) []reflect.Type {
-	if fn.NumOut() == 0 {
-		return emptyTypes
-	}
-
-	// check and decrease the loop size if Fn returns an error
-	size := fn.NumOut()
-	if hasErrorOut {
-		size--
-	}
-
-	types := make([]reflect.Type, size)
-	for i := 0; i < size; i++ {
-		types[i] = fn.Out(i)
-	}
-
-	return types
-}
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Task is a reflected task that will run.
+// Name: the Name of this task
+// Fn: the internal function that will run.
+// ReturnValueTypes: types of the return values. IMPORTANT: this will not include the error return
+type Task struct {
+	Name             string
+	Fn               reflect.Value
+	ReturnValueTypes []reflect.Type
+	hasErrorOut      bool
+	Cleanup          func() error
+	Retry            *RetryPolicy
+	// ResultNames, when set, names each of ReturnValueTypes in order so later
+	// tasks in a TaskChain can reference them via ParamBindings.
+	ResultNames []string
+	// ParamBindings, when set, wires each Fn input to an earlier task's named
+	// result using "taskName.resultName" instead of positional chaining.
+	ParamBindings []string
+}
+
+var (
+	emptyTypes = make([]reflect.Type, 0)
+)
+
+// RetryPolicy configures how a Task is retried when its Fn returns a
+// non-nil error. MaxAttempts <= 1 means the task is attempted exactly once.
+// Backoff is the wait before the second attempt; it is multiplied by
+// BackoffFactor (when > 0) after each subsequent failure, capped at
+// MaxBackoff (when > 0). ProgressDeadline, if set, starts when the first
+// attempt starts and is watched while waiting between attempts: if it passes
+// during a wait, the task fails with an error wrapping
+// context.DeadlineExceeded. An attempt that is already running is not
+// interrupted. Retryable, if set, is consulted before each retry and the
+// task fails immediately when it returns false.
+type RetryPolicy struct {
+	MaxAttempts      int
+	Backoff          time.Duration
+	BackoffFactor    float64
+	MaxBackoff       time.Duration
+	ProgressDeadline time.Duration
+	Retryable        func(error) bool
+}
+
+type TaskConfig struct {
+	Name    string
+	Cleanup func() error
+	Fn      interface{}
+	Retry   *RetryPolicy
+	// ResultNames, if set, must have one entry per non-error return value of
+	// Fn, naming it for ParamBindings.
+	ResultNames []string
+	// ParamBindings, if set, must have one entry per input of Fn, each a
+	// "taskName.resultName" reference to an earlier task's named result.
+	ParamBindings []string
+}
+
+// NewTask creates new Task instance
+func NewTask(config *TaskConfig) (*Task, error) {
+	if config.Fn == nil {
+		return nil, fmt.Errorf("failed to create task: '%s': Fn cannot be nil", config.Name)
+	}
+
+	fnType := reflect.TypeOf(config.Fn)
+
+	var err error
+
+	if err = isFunc(fnType); err != nil {
+		return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
+	}
+
+	hasErrOut := hasErrorOut(fnType)
+	returnValueTypes := getReturnValueTypes(fnType, hasErrOut)
+
+	if config.ResultNames != nil && len(config.ResultNames) != len(returnValueTypes) {
+		return nil, fmt.Errorf("failed to create task '%s': ResultNames has %d name(s) but Fn has %d output(s)", config.Name, len(config.ResultNames), len(returnValueTypes))
+	}
+
+	if config.ParamBindings != nil && len(config.ParamBindings) != fnType.NumIn() {
+		return nil, fmt.Errorf("failed to create task '%s': ParamBindings has %d entry(s) but Fn has %d input(s)", config.Name, len(config.ParamBindings), fnType.NumIn())
+	}
+
+	return &Task{
+		Fn:               reflect.ValueOf(config.Fn),
+		Name:             config.Name,
+		Cleanup:          config.Cleanup,
+		hasErrorOut:      hasErrOut,
+		ReturnValueTypes: returnValueTypes,
+		Retry:            config.Retry,
+		ResultNames:      config.ResultNames,
+		ParamBindings:    config.ParamBindings,
+	}, nil
+}
+
+// Run executes a function with given params, retrying according to Retry if
+// set. ctx is honored between attempts and may be nil.
+// the result of the Run will exclude the last error output if its Fn has an error out.
+func (t *Task) Run(ctx context.Context, params []reflect.Value) ([]reflect.Value, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if t.Retry == nil {
+		v, err := t.call(params)
+		return t.doCleanup(v, err)
+	}
+
+	v, err := t.runWithRetry(ctx, params)
+	return t.doCleanup(v, err)
+}
+
+// call invokes Fn once and splits off the trailing error return, if any.
+func (t *Task) call(params []reflect.Value) ([]reflect.Value, error) {
+	ret := t.Fn.Call(params)
+
+	if t.hasErrorOut { // has error out
+		if err, ok := ret[len(ret)-1].Interface().(error); ok && err != nil {
+			return nil, err
+		}
+		return ret[:len(ret)-1], nil
+	}
+
+	// return results as-is
+	return ret, nil
+}
+
+// runWithRetry calls Fn up to Retry.MaxAttempts times, waiting Retry.Backoff
+// (scaled by Retry.BackoffFactor after every failed attempt) between tries,
+// and gives up if Retry.ProgressDeadline passes during one of those waits.
+func (t *Task) runWithRetry(ctx context.Context, params []reflect.Value) ([]reflect.Value, error) {
+	policy := t.Retry
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var deadline <-chan time.Time
+	if policy.ProgressDeadline > 0 {
+		timer := time.NewTimer(policy.ProgressDeadline)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	backoff := policy.Backoff
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		v, err := t.call(params)
+		if err == nil {
+			return v, nil
+		}
+		lastErr = err
+
+		retryable := policy.Retryable == nil || policy.Retryable(err)
+		if !retryable || attempt == maxAttempts {
+			return nil, lastErr
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-deadline:
+			return nil, fmt.Errorf("task '%s' exceeded progress deadline: %w", t.Name, context.DeadlineExceeded)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if policy.BackoffFactor > 0 {
+			backoff = time.Duration(float64(backoff) * policy.BackoffFactor)
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+	}
+
+	return nil, lastErr
+}
+
+func (t *Task) nodeName() string { return t.Name }
+
+func (t *Task) outputTypes() []reflect.Type { return t.ReturnValueTypes }
+
+func (t *Task) inputTypes() []reflect.Type {
+	fnType := t.Fn.Type()
+	types := make([]reflect.Type, fnType.NumIn())
+	for i := range types {
+		types[i] = fnType.In(i)
+	}
+	return types
+}
+
+func (t *Task) doCleanup(v []reflect.Value, err error) ([]reflect.Value, error) {
+	if t.Cleanup != nil {
+		if cuErr := t.Cleanup(); cuErr != nil {
+			return v, errors.Join(cuErr, err)
+		}
+	}
+	return v, err
+}
+
+func hasErrorOut(fn reflect.Type) bool {
+	return fn.NumOut() > 0 && fn.Out(fn.NumOut()-1).AssignableTo(reflect.TypeFor[error]())
+}
+
+// getReturnValueTypes returns values-only types that excludes the last error type if exists
+func getReturnValueTypes(fn reflect.Type, hasErrorOut bool) []reflect.Type {
+	if fn.NumOut() == 0 {
+		return emptyTypes
+	}
+
+	// check and decrease the loop size if Fn returns an error
+	size := fn.NumOut()
+	if hasErrorOut {
+		size--
+	}
+
+	types := make([]reflect.Type, size)
+	for i := 0; i < size; i++ {
+		types[i] = fn.Out(i)
+	}
+
+	return types
+}
@@ -1,32 +1,34 @@
 package grace
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewTask(t *testing.T) {
 	t.Run("must return error when func is nil", func(t *testing.T) {
-		task, err := NewTask(&TaskConfig{"test", nil, nil})
+		task, err := NewTask(&TaskConfig{"test", nil, nil, nil, nil, nil})
 		assert.Error(t, err)
 		assert.Nil(t, task)
 	})
 
 	t.Run("must return not a function err", func(t *testing.T) {
-		task, err := NewTask(&TaskConfig{"test", nil, 10})
+		task, err := NewTask(&TaskConfig{"test", nil, 10, nil, nil, nil})
 		assert.Nil(t, task)
 		assert.ErrorIs(t, err, NotFuncErr)
 	})
 
 	t.Run("must handle no return type", func(t *testing.T) {
 		count := 0
-		task, err := NewTask(&TaskConfig{"my task", nil, func() { count++ }})
+		task, err := NewTask(&TaskConfig{"my task", nil, func() { count++ }, nil, nil, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, task)
-		v, err := task.Run(nil)
+		v, err := task.Run(context.Background(), nil)
 		assert.Nil(t, v)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, count)
@@ -35,9 +37,9 @@ func TestNewTask(t *testing.T) {
 
 func TestTask_Run(t *testing.T) {
 	t.Run("must return task return value", func(t *testing.T) {
-		task, err := NewTask(&TaskConfig{"test", nil, func() string { return "test_val" }})
+		task, err := NewTask(&TaskConfig{"test", nil, func() string { return "test_val" }, nil, nil, nil})
 		assert.NoError(t, err)
-		v, err := task.Run(nil)
+		v, err := task.Run(context.Background(), nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, v)
 		assert.Len(t, v, 1)
@@ -51,9 +53,9 @@ func TestTask_Run(t *testing.T) {
 		fn := func() (int, error) {
 			return -1, expectedErr
 		}
-		task, err := NewTask(&TaskConfig{"test", nil, fn})
+		task, err := NewTask(&TaskConfig{"test", nil, fn, nil, nil, nil})
 		assert.NoError(t, err)
-		v, err := task.Run(nil)
+		v, err := task.Run(context.Background(), nil)
 		assert.ErrorContains(t, err, expectedErr.Error())
 		assert.Nil(t, v)
 	})
@@ -62,9 +64,9 @@ func TestTask_Run(t *testing.T) {
 		fn := func(v int) (int, int, error) {
 			return v + 10, v - 10, nil
 		}
-		task, err := NewTask(&TaskConfig{"test", nil, fn})
+		task, err := NewTask(&TaskConfig{"test", nil, fn, nil, nil, nil})
 		assert.NoError(t, err)
-		v, err := task.Run([]reflect.Value{reflect.ValueOf(10)})
+		v, err := task.Run(context.Background(), []reflect.Value{reflect.ValueOf(10)})
 		assert.NoError(t, err)
 		assert.Len(t, v, 2)
 
@@ -83,13 +85,113 @@ func TestTask_Run(t *testing.T) {
 		task, err := NewTask(&TaskConfig{
 			"test",
 			func() error { return e1 },
-			func() error { return e2 }})
+			func() error { return e2 }, nil, nil, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, task)
 
-		v, err := task.Run(nil)
+		v, err := task.Run(context.Background(), nil)
 		assert.ErrorIs(t, err, e1)
 		assert.ErrorIs(t, err, e2)
 		assert.Nil(t, v)
 	})
 }
+
+func TestTask_RetryPolicy(t *testing.T) {
+	t.Run("must retry until success", func(t *testing.T) {
+		attempts := 0
+		task, err := NewTask(&TaskConfig{"test", nil, func() (int, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, errors.New("not yet")
+			}
+			return 42, nil
+		}, &RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond}, nil, nil})
+		assert.NoError(t, err)
+
+		v, err := task.Run(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, v[0].Interface())
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("must give up after MaxAttempts", func(t *testing.T) {
+		expectedErr := errors.New("always fails")
+		attempts := 0
+		task, err := NewTask(&TaskConfig{"test", nil, func() error {
+			attempts++
+			return expectedErr
+		}, &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}, nil, nil})
+		assert.NoError(t, err)
+
+		v, err := task.Run(context.Background(), nil)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Nil(t, v)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("must not retry when Retryable returns false", func(t *testing.T) {
+		expectedErr := errors.New("not retryable")
+		attempts := 0
+		task, err := NewTask(&TaskConfig{"test", nil, func() error {
+			attempts++
+			return expectedErr
+		}, &RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     time.Millisecond,
+			Retryable:   func(error) bool { return false },
+		}, nil, nil})
+		assert.NoError(t, err)
+
+		_, err = task.Run(context.Background(), nil)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("must fail with deadline exceeded when ProgressDeadline elapses", func(t *testing.T) {
+		task, err := NewTask(&TaskConfig{"test", nil, func() error {
+			return errors.New("still failing")
+		}, &RetryPolicy{
+			MaxAttempts:      100,
+			Backoff:          10 * time.Millisecond,
+			ProgressDeadline: 25 * time.Millisecond,
+		}, nil, nil})
+		assert.NoError(t, err)
+
+		_, err = task.Run(context.Background(), nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("must respect ctx cancellation between attempts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		task, err := NewTask(&TaskConfig{"test", nil, func() error {
+			attempts++
+			if attempts == 1 {
+				cancel()
+			}
+			return errors.New("fails")
+		}, &RetryPolicy{MaxAttempts: 5, Backoff: 10 * time.Millisecond}, nil, nil})
+		assert.NoError(t, err)
+
+		_, err = task.Run(ctx, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("must run Cleanup once after the final attempt", func(t *testing.T) {
+		attempts, cleanups := 0, 0
+		task, err := NewTask(&TaskConfig{"test", func() error {
+			cleanups++
+			return nil
+		}, func() error {
+			attempts++
+			return errors.New("fails")
+		}, &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}, nil, nil})
+		assert.NoError(t, err)
+
+		_, err = task.Run(context.Background(), nil)
+		assert.Error(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, 1, cleanups)
+	})
+}
@@ -1,114 +1,313 @@
-package grace
-
-import (
-	"context"
-	"errors"
-	"fmt"
-	"reflect"
-)
-
-const paramCountMismatchErrFmt = "failed to run task chain %s: parameter count does not match. expected: %v, actual: %v"
-
-// TaskChain consists a series of *Task to be run as a sequence
-type TaskChain[T any] struct {
-	Tasks     []*Task // 실행할 태스크들
-	Cleanup   func() error
-	Name      string
-	hasOutput bool
-}
-
-type TaskChainConfig struct {
-	Name    string
-	Cleanup func() error
-	Tasks   []*Task
-}
-
-// NewTaskChain returns a new TaskChain instance
-func NewTaskChain[T any](config *TaskChainConfig) (*TaskChain[T], error) {
-	tasks := config.Tasks
-
-	for i := 0; i < len(tasks); i++ {
-		if tasks[i] == nil {
-			tasks = append(tasks[:i], tasks[i+1:]...)
-			i--
-		}
-	}
-
-	size := len(tasks)
-
-	if size == 0 {
-		return &TaskChain[T]{
-			Tasks:     make([]*Task, 0),
-			hasOutput: false,
-		}, nil
-	}
-
-	for i := 1; i < size; i++ {
-		prev := tasks[i-1]
-		curr := tasks[i]
-		if err := verifyTaskCompatibility(prev, curr); err != nil {
-			return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
-		}
-	}
-
-	last := tasks[size-1]
-	hasLastTaskOut := len(last.ReturnValueTypes) > 0
-
-	if err := verifyReturnType[T](tasks[size-1]); err != nil {
-		return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
-	}
-
-	return &TaskChain[T]{
-		Tasks:     tasks,
-		hasOutput: hasLastTaskOut,
-		Cleanup:   config.Cleanup,
-		Name:      config.Name,
-	}, nil
-}
-
-func Zero[T any]() T {
-	return *new(T)
-}
-
-// Run a series of Task in this TaskChain.
-func (tc *TaskChain[T]) Run(ctx context.Context, params ...interface{}) (T, error) {
-	if len(tc.Tasks) == 0 {
-		return tc.doCleanup(Zero[T](), nil)
-	}
-
-	if err := verifyInitialParams(tc.Tasks[0].Fn.Type(), params); err != nil {
-		return tc.doCleanup(Zero[T](), fmt.Errorf("error running %s: %w", tc.Name, err))
-	}
-
-	var currentResults = make([]reflect.Value, len(params))
-
-	for i := range params {
-		currentResults[i] = reflect.ValueOf(params[i])
-	}
-
-	for i, t := range tc.Tasks {
-		if ctx != nil && ctx.Err() != nil {
-			return tc.doCleanup(Zero[T](), ctx.Err())
-		}
-		results, err := t.Run(currentResults)
-		if err != nil {
-			return tc.doCleanup(Zero[T](), fmt.Errorf("error running task %d: %w", i, err))
-		}
-		currentResults = results
-	}
-
-	if len(currentResults) == 0 {
-		return tc.doCleanup(Zero[T](), nil)
-	}
-
-	return tc.doCleanup(currentResults[len(currentResults)-1].Interface().(T), nil)
-}
-
-func (tc *TaskChain[T]) doCleanup(v T, err error) (T, error) {
-	if tc.Cleanup != nil {
-		if e := tc.Cleanup(); e != nil {
-			return v, errors.Join(e, err)
-		}
-	}
-	return v, err
-}
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const paramCountMismatchErrFmt = "failed to run task chain %s: parameter count does not match. expected: %v, actual: %v"
+
+// Node is a single step in a TaskChain: either a *Task or a *TaskGroup. It is
+// implemented only by those two types.
+type Node interface {
+	nodeName() string
+	inputTypes() []reflect.Type
+	outputTypes() []reflect.Type
+	Run(ctx context.Context, params []reflect.Value) ([]reflect.Value, error)
+}
+
+// TaskChain consists a series of Node (each a *Task or *TaskGroup) to be run as a sequence
+type TaskChain[T any] struct {
+	Tasks     []Node // 실행할 태스크들
+	Finally   []*Task
+	Cleanup   func() error
+	Name      string
+	hasOutput bool
+	// named is true when any Task in Tasks declares ParamBindings, switching
+	// Run from positional chaining to binding-resolved named results.
+	named bool
+}
+
+type TaskChainConfig struct {
+	Name    string
+	Cleanup func() error
+	Tasks   []Node
+	// Finally tasks are run, in order, after Tasks finish for any reason
+	// (success, error, context cancellation or panic). See TaskChainStatus.
+	Finally []*Task
+}
+
+// TaskChainStatus describes how a TaskChain finished and is passed to a
+// Finally task whose Fn declares a single input that it is assignable to.
+type TaskChainStatus struct {
+	Err         error
+	LastResults []reflect.Value
+	TaskIndex   int
+	Name        string
+}
+
+func filterNilTasks(tasks []*Task) []*Task {
+	for i := 0; i < len(tasks); i++ {
+		if tasks[i] == nil {
+			tasks = append(tasks[:i], tasks[i+1:]...)
+			i--
+		}
+	}
+	return tasks
+}
+
+// isNilNode reports whether n is nil, either as a bare Node interface or as
+// a typed *Task/*TaskGroup nil pointer boxed inside one (the latter is what
+// a caller gets from e.g. `t, _ := NewTask(...)` on error).
+func isNilNode(n Node) bool {
+	switch v := n.(type) {
+	case nil:
+		return true
+	case *Task:
+		return v == nil
+	case *TaskGroup:
+		return v == nil
+	default:
+		return false
+	}
+}
+
+func filterNilNodes(nodes []Node) []Node {
+	for i := 0; i < len(nodes); i++ {
+		if isNilNode(nodes[i]) {
+			nodes = append(nodes[:i], nodes[i+1:]...)
+			i--
+		}
+	}
+	return nodes
+}
+
+// NewTaskChain returns a new TaskChain instance
+func NewTaskChain[T any](config *TaskChainConfig) (*TaskChain[T], error) {
+	nodes := filterNilNodes(config.Tasks)
+	finally := filterNilTasks(config.Finally)
+
+	for _, ft := range finally {
+		if err := verifyFinallyTask(ft); err != nil {
+			return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
+		}
+	}
+
+	size := len(nodes)
+
+	if size == 0 {
+		return &TaskChain[T]{
+			Tasks:     make([]Node, 0),
+			Finally:   finally,
+			hasOutput: false,
+		}, nil
+	}
+
+	named := isNamedChain(nodes)
+
+	if named {
+		if err := verifyTaskBindings(nodes); err != nil {
+			return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
+		}
+	} else {
+		for i := 1; i < size; i++ {
+			prev := nodes[i-1]
+			curr := nodes[i]
+			if err := verifyNodeCompatibility(prev, curr); err != nil {
+				return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
+			}
+		}
+	}
+
+	last := nodes[size-1]
+	hasLastTaskOut := len(last.outputTypes()) > 0
+
+	if err := verifyLastNodeReturnType[T](last); err != nil {
+		return nil, fmt.Errorf("failed to create task '%s': %w", config.Name, err)
+	}
+
+	return &TaskChain[T]{
+		Tasks:     nodes,
+		Finally:   finally,
+		hasOutput: hasLastTaskOut,
+		Cleanup:   config.Cleanup,
+		Name:      config.Name,
+		named:     named,
+	}, nil
+}
+
+// isNamedChain reports whether any task in the chain declares ParamBindings,
+// which switches the chain from positional chaining to binding-resolved
+// named results.
+func isNamedChain(nodes []Node) bool {
+	for _, n := range nodes {
+		if t, ok := n.(*Task); ok && len(t.ParamBindings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func Zero[T any]() T {
+	return *new(T)
+}
+
+// Run a series of Task in this TaskChain. Once Tasks finish, for any reason,
+// the chain's Finally tasks run before Run returns.
+func (tc *TaskChain[T]) Run(ctx context.Context, params ...interface{}) (result T, err error) {
+	var (
+		taskIndex   = -1
+		lastResults []reflect.Value
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = tc.doCleanup(Zero[T](), fmt.Errorf("panic running task chain %s: %v", tc.Name, r))
+		}
+		result, err = tc.runFinally(ctx, result, err, taskIndex, lastResults)
+	}()
+
+	if len(tc.Tasks) == 0 {
+		result, err = tc.doCleanup(Zero[T](), nil)
+		return
+	}
+
+	if verifyErr := verifyInitialNodeParams(tc.Tasks[0], params); verifyErr != nil {
+		result, err = tc.doCleanup(Zero[T](), fmt.Errorf("error running %s: %w", tc.Name, verifyErr))
+		return
+	}
+
+	if tc.named {
+		result, err = tc.runNamed(ctx, params, &taskIndex, &lastResults)
+		return
+	}
+
+	currentResults := make([]reflect.Value, len(params))
+
+	for i := range params {
+		currentResults[i] = reflect.ValueOf(params[i])
+	}
+
+	for i, node := range tc.Tasks {
+		taskIndex = i
+
+		if ctx != nil && ctx.Err() != nil {
+			result, err = tc.doCleanup(Zero[T](), ctx.Err())
+			return
+		}
+
+		results, runErr := node.Run(ctx, currentResults)
+		if runErr != nil {
+			result, err = tc.doCleanup(Zero[T](), fmt.Errorf("error running task %d: %w", i, runErr))
+			return
+		}
+
+		currentResults = results
+		lastResults = currentResults
+	}
+
+	if len(currentResults) == 0 {
+		result, err = tc.doCleanup(Zero[T](), nil)
+		return
+	}
+
+	result, err = tc.doCleanup(currentResults[len(currentResults)-1].Interface().(T), nil)
+	return
+}
+
+// runNamed executes tc.Tasks using binding-resolved named results instead of
+// positional chaining: the first task still receives params like the
+// positional path, and every later task's arguments are assembled from its
+// ParamBindings by looking up "taskName.resultName" in the published results
+// of tasks that already ran.
+func (tc *TaskChain[T]) runNamed(ctx context.Context, params []interface{}, taskIndex *int, lastResults *[]reflect.Value) (T, error) {
+	published := make(map[string]reflect.Value)
+
+	firstArgs := make([]reflect.Value, len(params))
+	for i := range params {
+		firstArgs[i] = reflect.ValueOf(params[i])
+	}
+
+	var currentResults []reflect.Value
+
+	for i, node := range tc.Tasks {
+		*taskIndex = i
+
+		if ctx != nil && ctx.Err() != nil {
+			return tc.doCleanup(Zero[T](), ctx.Err())
+		}
+
+		// verifyTaskBindings guarantees every node is a *Task in named mode.
+		t := node.(*Task)
+
+		var args []reflect.Value
+		if i == 0 {
+			args = firstArgs
+		} else {
+			args = make([]reflect.Value, len(t.ParamBindings))
+			for paramIdx, binding := range t.ParamBindings {
+				args[paramIdx] = published[binding]
+			}
+		}
+
+		results, runErr := t.Run(ctx, args)
+		if runErr != nil {
+			return tc.doCleanup(Zero[T](), fmt.Errorf("error running task %d: %w", i, runErr))
+		}
+
+		for resultIdx, name := range t.ResultNames {
+			published[t.Name+"."+name] = results[resultIdx]
+		}
+
+		currentResults = results
+		*lastResults = currentResults
+	}
+
+	if len(currentResults) == 0 {
+		return tc.doCleanup(Zero[T](), nil)
+	}
+
+	return tc.doCleanup(currentResults[len(currentResults)-1].Interface().(T), nil)
+}
+
+// runFinally invokes every Finally task in order after Tasks have finished.
+// Each task receives no arguments if its Fn takes none, or a TaskChainStatus
+// describing the run if its Fn takes one. A Finally task's own error does not
+// stop the remaining Finally tasks from running; it is joined into the
+// chain's result error.
+func (tc *TaskChain[T]) runFinally(ctx context.Context, result T, chainErr error, taskIndex int, lastResults []reflect.Value) (T, error) {
+	if len(tc.Finally) == 0 {
+		return result, chainErr
+	}
+
+	status := TaskChainStatus{
+		Err:         chainErr,
+		LastResults: lastResults,
+		TaskIndex:   taskIndex,
+		Name:        tc.Name,
+	}
+
+	for _, ft := range tc.Finally {
+		var args []reflect.Value
+		if ft.Fn.Type().NumIn() == 1 {
+			args = []reflect.Value{reflect.ValueOf(status)}
+		}
+
+		if _, ftErr := ft.Run(ctx, args); ftErr != nil {
+			chainErr = errors.Join(chainErr, fmt.Errorf("error running finally task '%s': %w", ft.Name, ftErr))
+		}
+	}
+
+	return result, chainErr
+}
+
+func (tc *TaskChain[T]) doCleanup(v T, err error) (T, error) {
+	if tc.Cleanup != nil {
+		if e := tc.Cleanup(); e != nil {
+			return v, errors.Join(e, err)
+		}
+	}
+	return v, err
+}
@@ -9,6 +9,17 @@ import (
 	"time"
 )
 
+// Animal and Dog let tests assert that producer/consumer compatibility
+// checks accept a concrete return type feeding a wider interface input, not
+// just matching concrete types.
+type Animal interface {
+	Sound() string
+}
+
+type Dog struct{}
+
+func (Dog) Sound() string { return "woof" }
+
 func TestNewTaskChain(t *testing.T) {
 	noOpCleanFn := func() error { return nil }
 	first, err := NewTask(&TaskConfig{
@@ -35,7 +46,7 @@ func TestNewTaskChain(t *testing.T) {
 		chain, err := NewTaskChain[struct{}](&TaskChainConfig{
 			Name:    "",
 			Cleanup: nil,
-			Tasks:   []*Task{nil, nil, nil},
+			Tasks:   []Node{nil, nil, nil},
 		})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
@@ -49,7 +60,8 @@ func TestNewTaskChain(t *testing.T) {
 		chain, err := NewTaskChain[struct{}](&TaskChainConfig{
 			"test",
 			noOpCleanFn,
-			[]*Task{nil, second},
+			[]Node{nil, second},
+			nil,
 		})
 		assert.ErrorContains(t, err, "struct {}")
 		assert.ErrorContains(t, err, "int")
@@ -57,28 +69,40 @@ func TestNewTaskChain(t *testing.T) {
 	})
 
 	t.Run("must report output and input count mismatch", func(t *testing.T) {
-		t1, _ := NewTask(&TaskConfig{"test1", nil, func() {}})
-		t2, _ := NewTask(&TaskConfig{"test2", nil, func(a, b int) int { return 0 }})
-		chain, err := NewTaskChain[int](&TaskChainConfig{"chain", noOpCleanFn, []*Task{t1, t2}})
+		t1, _ := NewTask(&TaskConfig{"test1", nil, func() {}, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"test2", nil, func(a, b int) int { return 0 }, nil, nil, nil})
+		chain, err := NewTaskChain[int](&TaskChainConfig{"chain", noOpCleanFn, []Node{t1, t2}, nil})
 		assert.ErrorContains(t, err, "2")
 		assert.ErrorContains(t, err, "1")
 		assert.Nil(t, chain)
 	})
 
 	t.Run("must report output and input type mismatch", func(t *testing.T) {
-		t1, _ := NewTask(&TaskConfig{"test1", nil, func() int { return 0 }})
-		t2, _ := NewTask(&TaskConfig{"test2", nil, func(s string) int { return 0 }})
-		chain, err := NewTaskChain[int](&TaskChainConfig{"chain", noOpCleanFn, []*Task{t1, t2}})
+		t1, _ := NewTask(&TaskConfig{"test1", nil, func() int { return 0 }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"test2", nil, func(s string) int { return 0 }, nil, nil, nil})
+		chain, err := NewTaskChain[int](&TaskChainConfig{"chain", noOpCleanFn, []Node{t1, t2}, nil})
 		assert.ErrorContains(t, err, "string")
 		assert.ErrorContains(t, err, "int")
 		assert.Nil(t, chain)
 	})
 
+	t.Run("must accept a concrete return type feeding a wider interface input", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() Dog { return Dog{} }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a Animal) string { return a.Sound() }, nil, nil, nil})
+		chain, err := NewTaskChain[string](&TaskChainConfig{"chain", nil, []Node{t1, t2}, nil})
+		assert.NoError(t, err)
+		assert.NotNil(t, chain)
+
+		v, err := chain.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "woof", v)
+	})
+
 	t.Run("must handle no output func", func(t *testing.T) {
 		x := 0
-		t1, _ := NewTask(&TaskConfig{"t1", nil, func(a, b, c int) int { return a + b*c }})
-		t2, _ := NewTask(&TaskConfig{"t2", nil, func(i int) { x = i * 10 }})
-		chain, err := NewTaskChain[int](&TaskChainConfig{"test", noOpCleanFn, []*Task{t1, t2}})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func(a, b, c int) int { return a + b*c }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(i int) { x = i * 10 }, nil, nil, nil})
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", noOpCleanFn, []Node{t1, t2}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 		r, err := chain.Run(context.Background(), 10, 3, 5)
@@ -89,16 +113,16 @@ func TestNewTaskChain(t *testing.T) {
 
 	t.Run("must handle error from chain", func(t *testing.T) {
 		expectedErr := errors.New("my test error")
-		t1, err := NewTask(&TaskConfig{"t1", nil, func(a, b, c int) (int, error) { return a + b + c, nil }})
+		t1, err := NewTask(&TaskConfig{"t1", nil, func(a, b, c int) (int, error) { return a + b + c, nil }, nil, nil, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, t1)
 
-		t2, err := NewTask(&TaskConfig{"t2", nil, func(a int) (string, error) { return strconv.Itoa(a), expectedErr }})
+		t2, err := NewTask(&TaskConfig{"t2", nil, func(a int) (string, error) { return strconv.Itoa(a), expectedErr }, nil, nil, nil})
 
 		invoked := 0
-		t3, err := NewTask(&TaskConfig{"t3", nil, func(s string) { invoked++ }})
+		t3, err := NewTask(&TaskConfig{"t3", nil, func(s string) { invoked++ }, nil, nil, nil})
 
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []*Task{t1, t2, t3}})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []Node{t1, t2, t3}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 
@@ -108,7 +132,7 @@ func TestNewTaskChain(t *testing.T) {
 	})
 
 	t.Run("must return value", func(t *testing.T) {
-		chain, err := NewTaskChain[int](&TaskChainConfig{"test_chain", nil, []*Task{first, second}})
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test_chain", nil, []Node{first, second}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 		v, err := chain.Run(context.Background(), "10")
@@ -117,7 +141,7 @@ func TestNewTaskChain(t *testing.T) {
 	})
 
 	t.Run("must run with nil context", func(t *testing.T) {
-		chain, err := NewTaskChain[int](&TaskChainConfig{"test_chain", nil, []*Task{first, second}})
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test_chain", nil, []Node{first, second}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 		v, err := chain.Run(nil, "10")
@@ -129,10 +153,10 @@ func TestNewTaskChain(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 
 		c1, c2 := 0, 0
-		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { cancel(); c1++ }})
-		t2, _ := NewTask(&TaskConfig{"t2", nil, func() { c2++ }})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { cancel(); c1++ }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func() { c2++ }, nil, nil, nil})
 
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []*Task{t1, t2}})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []Node{t1, t2}, nil})
 		assert.NoError(t, err)
 
 		v, err := chain.Run(ctx)
@@ -148,10 +172,10 @@ func TestNewTaskChain(t *testing.T) {
 
 		delay := time.Millisecond * 100
 		c1, c2 := 0, 0
-		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { c1++; time.Sleep(delay) }})
-		t2, _ := NewTask(&TaskConfig{"t2", nil, func() { c2++ }})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { c1++; time.Sleep(delay) }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func() { c2++ }, nil, nil, nil})
 
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []*Task{t1, t2}})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []Node{t1, t2}, nil})
 		assert.NoError(t, err)
 
 		begin := time.Now()
@@ -172,7 +196,7 @@ func TestNewTaskChain(t *testing.T) {
 				count++
 				return nil
 			},
-			[]*Task{first, second}})
+			[]Node{first, second}, nil})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
@@ -188,12 +212,12 @@ func TestNewTaskChain(t *testing.T) {
 		count := 0
 		testErr := errors.New("test error")
 		cleanupErr := errors.New("Cleanup error")
-		t1, _ := NewTask(&TaskConfig{"test task", nil, func() error { count++; return testErr }})
+		t1, _ := NewTask(&TaskConfig{"test task", nil, func() error { count++; return testErr }, nil, nil, nil})
 		chain, err := NewTaskChain[any](
 			&TaskChainConfig{"test task chain", func() error {
 				count++
 				return cleanupErr
-			}, []*Task{t1}})
+			}, []Node{t1}, nil})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
@@ -205,8 +229,8 @@ func TestNewTaskChain(t *testing.T) {
 	})
 
 	t.Run("must return error when args size does not match", func(t *testing.T) {
-		t1, _ := NewTask(&TaskConfig{"test_task", nil, func(a, b, c int) {}})
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []*Task{t1}})
+		t1, _ := NewTask(&TaskConfig{"test_task", nil, func(a, b, c int) {}, nil, nil, nil})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []Node{t1}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 
@@ -218,8 +242,8 @@ func TestNewTaskChain(t *testing.T) {
 	})
 
 	t.Run("must return error when args type does not match", func(t *testing.T) {
-		t1, _ := NewTask(&TaskConfig{"test_task", nil, func(a, b, c int) {}})
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []*Task{t1}})
+		t1, _ := NewTask(&TaskConfig{"test_task", nil, func(a, b, c int) {}, nil, nil, nil})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []Node{t1}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 
@@ -235,16 +259,17 @@ func TestNewTaskChain(t *testing.T) {
 		var a, b, c, d, e int
 		var x int
 		testErr := errors.New("test error")
-		t1, _ := NewTask(&TaskConfig{"t1", func() error { a += 1; return nil }, func() {}})
-		t2, _ := NewTask(&TaskConfig{"t2", func() error { b += 2; return nil }, func() {}})
-		t3, _ := NewTask(&TaskConfig{"t3", func() error { c += 3; return nil }, func() {}})
-		t4, _ := NewTask(&TaskConfig{"t4", func() error { d += 4; return testErr }, func() {}})
-		t5, _ := NewTask(&TaskConfig{"t5", func() error { e += 5; return nil }, func() {}})
+		t1, _ := NewTask(&TaskConfig{"t1", func() error { a += 1; return nil }, func() {}, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", func() error { b += 2; return nil }, func() {}, nil, nil, nil})
+		t3, _ := NewTask(&TaskConfig{"t3", func() error { c += 3; return nil }, func() {}, nil, nil, nil})
+		t4, _ := NewTask(&TaskConfig{"t4", func() error { d += 4; return testErr }, func() {}, nil, nil, nil})
+		t5, _ := NewTask(&TaskConfig{"t5", func() error { e += 5; return nil }, func() {}, nil, nil, nil})
 
 		chain, err := NewTaskChain[any](&TaskChainConfig{
 			"test_task_chain",
 			func() error { x++; return nil },
-			[]*Task{t1, t2, t3, t4, t5},
+			[]Node{t1, t2, t3, t4, t5},
+			nil,
 		})
 		assert.NoError(t, err)
 		v, err := chain.Run(context.Background())
@@ -263,11 +288,11 @@ func TestNewTaskChain(t *testing.T) {
 		defer cancel()
 
 		var a, b, c int
-		t1, _ := NewTask(&TaskConfig{"t1", func() error { a += 1; return nil }, func() { time.Sleep(time.Millisecond * 50) }})
-		t2, _ := NewTask(&TaskConfig{"t2", func() error { b += 2; return nil }, func() { time.Sleep(time.Millisecond * 100) }})
-		t3, _ := NewTask(&TaskConfig{"t3", func() error { c += 3; return nil }, func() { time.Sleep(time.Millisecond * 80) }})
+		t1, _ := NewTask(&TaskConfig{"t1", func() error { a += 1; return nil }, func() { time.Sleep(time.Millisecond * 50) }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", func() error { b += 2; return nil }, func() { time.Sleep(time.Millisecond * 100) }, nil, nil, nil})
+		t3, _ := NewTask(&TaskConfig{"t3", func() error { c += 3; return nil }, func() { time.Sleep(time.Millisecond * 80) }, nil, nil, nil})
 
-		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []*Task{t1, t2, t3}})
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_task_chain", nil, []Node{t1, t2, t3}, nil})
 		assert.NoError(t, err)
 		assert.NotNil(t, chain)
 
@@ -280,3 +305,187 @@ func TestNewTaskChain(t *testing.T) {
 		assert.Equal(t, 0, c)
 	})
 }
+
+func TestTaskChain_Finally(t *testing.T) {
+	t.Run("must reject finally task with too many inputs", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() {}, nil, nil, nil})
+		finally, _ := NewTask(&TaskConfig{"finally", nil, func(a, b int) {}, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test", nil, []Node{t1}, []*Task{finally}})
+		assert.ErrorIs(t, err, IncompatibleFunctionSignatureErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must reject finally task whose input is not a TaskChainStatus", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() {}, nil, nil, nil})
+		finally, _ := NewTask(&TaskConfig{"finally", nil, func(s string) {}, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test", nil, []Node{t1}, []*Task{finally}})
+		assert.ErrorIs(t, err, IncompatibleFunctionSignatureErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must run finally tasks on success with no input", func(t *testing.T) {
+		invoked := 0
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 42 }, nil, nil, nil})
+		finally, _ := NewTask(&TaskConfig{"finally", nil, func() { invoked++ }, nil, nil, nil})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1}, []*Task{finally}})
+		assert.NoError(t, err)
+
+		v, err := chain.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 42, v)
+		assert.Equal(t, 1, invoked)
+	})
+
+	t.Run("must pass TaskChainStatus and run on error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		var status TaskChainStatus
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() error { return testErr }, nil, nil, nil})
+		finally, _ := NewTask(&TaskConfig{"finally", nil, func(s TaskChainStatus) {
+			status = s
+		}, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test_chain", nil, []Node{t1}, []*Task{finally}})
+		assert.NoError(t, err)
+
+		v, err := chain.Run(context.Background())
+		assert.ErrorIs(t, err, testErr)
+		assert.Nil(t, v)
+
+		assert.ErrorIs(t, status.Err, testErr)
+		assert.Equal(t, 0, status.TaskIndex)
+		assert.Equal(t, "test_chain", status.Name)
+	})
+
+	t.Run("must run every finally task even if one errors, and join errors", func(t *testing.T) {
+		testErr := errors.New("test error")
+		finallyErr1 := errors.New("finally error 1")
+		finallyErr2 := errors.New("finally error 2")
+
+		invoked1, invoked2 := false, false
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() error { return testErr }, nil, nil, nil})
+		f1, _ := NewTask(&TaskConfig{"f1", nil, func() error { invoked1 = true; return finallyErr1 }, nil, nil, nil})
+		f2, _ := NewTask(&TaskConfig{"f2", nil, func() error { invoked2 = true; return finallyErr2 }, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test", nil, []Node{t1}, []*Task{f1, f2}})
+		assert.NoError(t, err)
+
+		_, err = chain.Run(context.Background())
+		assert.ErrorIs(t, err, testErr)
+		assert.ErrorIs(t, err, finallyErr1)
+		assert.ErrorIs(t, err, finallyErr2)
+		assert.True(t, invoked1)
+		assert.True(t, invoked2)
+	})
+
+	t.Run("must run finally tasks after a panic", func(t *testing.T) {
+		invoked := false
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { panic("boom") }, nil, nil, nil})
+		finally, _ := NewTask(&TaskConfig{"finally", nil, func(s TaskChainStatus) {
+			invoked = true
+			assert.ErrorContains(t, s.Err, "boom")
+		}, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test", nil, []Node{t1}, []*Task{finally}})
+		assert.NoError(t, err)
+
+		v, err := chain.Run(context.Background())
+		assert.Nil(t, v)
+		assert.ErrorContains(t, err, "boom")
+		assert.True(t, invoked)
+	})
+
+	t.Run("must run chain Cleanup after a panic", func(t *testing.T) {
+		count := 0
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() { panic("boom") }, nil, nil, nil})
+
+		chain, err := NewTaskChain[any](&TaskChainConfig{"test", func() error {
+			count++
+			return nil
+		}, []Node{t1}, nil})
+		assert.NoError(t, err)
+
+		v, err := chain.Run(context.Background())
+		assert.Nil(t, v)
+		assert.ErrorContains(t, err, "boom")
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestTaskChain_NamedResults(t *testing.T) {
+	t.Run("must reject first task declaring ParamBindings", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func(a int) int { return a }, nil, nil, []string{"whatever"}})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a int) int { return a }, nil, nil, []string{"t1.out"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1, t2}, nil})
+		assert.ErrorIs(t, err, MissingParamBindingErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must reject binding referencing unknown task", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, []string{"out"}, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a int) int { return a }, nil, nil, []string{"unknown.out"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1, t2}, nil})
+		assert.ErrorIs(t, err, MissingParamBindingErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must reject binding to a task that runs after it", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, []string{"out"}, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a int) int { return a }, nil, []string{"out"}, []string{"t3.out"}})
+		t3, _ := NewTask(&TaskConfig{"t3", nil, func(a int) int { return a }, nil, []string{"out"}, []string{"t1.out"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1, t2, t3}, nil})
+		assert.ErrorIs(t, err, MissingParamBindingErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must reject binding referencing unknown result name", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, []string{"out"}, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a int) int { return a }, nil, nil, []string{"t1.missing"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1, t2}, nil})
+		assert.ErrorIs(t, err, MissingParamBindingErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must reject binding whose type is not assignable", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() string { return "1" }, nil, []string{"out"}, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a int) int { return a }, nil, nil, []string{"t1.out"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{t1, t2}, nil})
+		assert.ErrorIs(t, err, IncompatibleFunctionSignatureErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must run end to end using named results", func(t *testing.T) {
+		start, _ := NewTask(&TaskConfig{"start", nil, func(a int) int { return a }, nil, []string{"val"}, nil})
+		double, _ := NewTask(&TaskConfig{"double", nil, func(a int) int { return a * 2 }, nil, []string{"val"}, []string{"start.val"}})
+		triple, _ := NewTask(&TaskConfig{"triple", nil, func(a int) int { return a * 3 }, nil, []string{"val"}, []string{"start.val"}})
+		sum, _ := NewTask(&TaskConfig{"sum", nil, func(a, b int) int { return a + b }, nil, []string{"total"}, []string{"double.val", "triple.val"}})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{start, double, triple, sum}, nil})
+		assert.NoError(t, err)
+		assert.NotNil(t, chain)
+
+		v, err := chain.Run(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, v)
+	})
+
+	t.Run("must accept a binding whose produced type is a concrete type feeding a wider interface input", func(t *testing.T) {
+		start, _ := NewTask(&TaskConfig{"start", nil, func() Dog { return Dog{} }, nil, []string{"pet"}, nil})
+		speak, _ := NewTask(&TaskConfig{"speak", nil, func(a Animal) string { return a.Sound() }, nil, []string{"sound"}, []string{"start.pet"}})
+
+		chain, err := NewTaskChain[string](&TaskChainConfig{"test", nil, []Node{start, speak}, nil})
+		assert.NoError(t, err)
+		assert.NotNil(t, chain)
+
+		v, err := chain.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "woof", v)
+	})
+}
@@ -0,0 +1,383 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	DuplicateTaskNameErr = errors.New("duplicate task name")
+	UnknownDependencyErr = errors.New("unknown dependency")
+	CyclicDependencyErr  = errors.New("cyclic dependency detected")
+	AmbiguousSinkErr     = errors.New("ambiguous result: graph has more than one sink node")
+)
+
+// graphNode holds a Task together with the names of the tasks it depends on.
+type graphNode struct {
+	name string
+	task *Task
+	deps []string
+}
+
+type TaskGraphConfig struct {
+	Name       string
+	Cleanup    func() error
+	MaxWorkers int
+}
+
+// TaskGraph executes a set of named Task as a DAG instead of a linear
+// sequence: a task only runs once every task named in its dependencies has
+// completed, and independent tasks run concurrently.
+type TaskGraph[T any] struct {
+	Name       string
+	Cleanup    func() error
+	MaxWorkers int
+
+	nodes map[string]*graphNode
+	order []string
+	sink  string
+	built bool
+}
+
+// NewTaskGraph returns an empty TaskGraph. Tasks must be registered with
+// AddTask and the graph finalized with Build (or implicitly on first Run)
+// before it can execute.
+func NewTaskGraph[T any](config *TaskGraphConfig) *TaskGraph[T] {
+	if config == nil {
+		config = &TaskGraphConfig{}
+	}
+
+	return &TaskGraph[T]{
+		Name:       config.Name,
+		Cleanup:    config.Cleanup,
+		MaxWorkers: config.MaxWorkers,
+		nodes:      make(map[string]*graphNode),
+	}
+}
+
+// AddTask registers task under name with the given dependency names. deps may
+// reference tasks added before or after this call; they are resolved when the
+// graph is built. AddTask returns an error if name was already registered.
+func (g *TaskGraph[T]) AddTask(name string, deps []string, task *Task) error {
+	if task == nil {
+		return fmt.Errorf("failed to add task '%s' to graph '%s': task cannot be nil", name, g.Name)
+	}
+
+	if _, exists := g.nodes[name]; exists {
+		return errors.Join(DuplicateTaskNameErr, fmt.Errorf("task '%s' is already registered in graph '%s'", name, g.Name))
+	}
+
+	g.nodes[name] = &graphNode{name: name, task: task, deps: deps}
+	g.built = false
+
+	return nil
+}
+
+// Build validates the graph: every dependency name must resolve to a
+// registered task, the dependency graph must be acyclic, and for every node
+// its Fn inputs must be the concatenation of its dependencies' return value
+// types, in declared order. It also resolves the single sink node (the node
+// nothing else depends on) and checks its last output type against T. An
+// empty graph (no tasks added) builds successfully with no sink, matching
+// TaskChain's handling of an empty Tasks slice. Build is idempotent and is
+// called automatically by Run if it has not been called yet.
+func (g *TaskGraph[T]) Build() error {
+	if len(g.nodes) == 0 {
+		g.order = nil
+		g.sink = ""
+		g.built = true
+		return nil
+	}
+
+	for name, node := range g.nodes {
+		for _, dep := range node.deps {
+			if _, ok := g.nodes[dep]; !ok {
+				return errors.Join(UnknownDependencyErr, fmt.Errorf("task '%s' in graph '%s' depends on unknown task '%s'", name, g.Name, dep))
+			}
+		}
+	}
+
+	order, err := topoSortGraph(g.nodes)
+	if err != nil {
+		return fmt.Errorf("failed to build task graph '%s': %w", g.Name, err)
+	}
+
+	for _, name := range order {
+		if err := verifyGraphNodeCompatibility(g.nodes, g.nodes[name]); err != nil {
+			return fmt.Errorf("failed to build task graph '%s': %w", g.Name, err)
+		}
+	}
+
+	sink, err := findSink(g.nodes)
+	if err != nil {
+		return fmt.Errorf("failed to build task graph '%s': %w", g.Name, err)
+	}
+
+	if err := verifyReturnType[T](g.nodes[sink].task); err != nil {
+		return fmt.Errorf("failed to build task graph '%s': %w", g.Name, err)
+	}
+
+	g.order = order
+	g.sink = sink
+	g.built = true
+
+	return nil
+}
+
+// Run executes every registered task, honoring dependency order, and returns
+// the sink node's last return value. Source nodes (those declared with no
+// dependencies) all receive params, exactly like the first task of a
+// TaskChain. Independent tasks run concurrently, up to MaxWorkers at a time
+// (unbounded when MaxWorkers <= 0). The first non-nil error returned by any
+// task cancels the ctx derived for the remaining tasks; tasks already
+// running are allowed to finish.
+func (g *TaskGraph[T]) Run(ctx context.Context, params ...interface{}) (T, error) {
+	if !g.built {
+		if err := g.Build(); err != nil {
+			return Zero[T](), err
+		}
+	}
+
+	if len(g.nodes) == 0 {
+		return g.doCleanup(Zero[T](), nil)
+	}
+
+	initialArgs := make([]reflect.Value, len(params))
+	for i, p := range params {
+		initialArgs[i] = reflect.ValueOf(p)
+	}
+
+	for _, node := range g.nodes {
+		if len(node.deps) == 0 {
+			if err := verifyInitialParams(node.task.Fn.Type(), params); err != nil {
+				return g.doCleanup(Zero[T](), fmt.Errorf("error running graph %s: %w", g.Name, err))
+			}
+		}
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxWorkers := g.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = len(g.nodes)
+	}
+	sem := make(chan struct{}, maxWorkers)
+
+	done := make(map[string]chan struct{}, len(g.nodes))
+	for name := range g.nodes {
+		done[name] = make(chan struct{})
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make(map[string][]reflect.Value, len(g.nodes))
+		firstErr error
+		errOnce  sync.Once
+	)
+
+	for _, name := range g.order {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer close(done[name])
+			defer func() {
+				if r := recover(); r != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("panic running task '%s': %v", name, r)
+						cancel()
+					})
+				}
+			}()
+
+			node := g.nodes[name]
+			for _, dep := range node.deps {
+				select {
+				case <-done[dep]:
+				case <-runCtx.Done():
+					return
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-runCtx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			if runCtx.Err() != nil {
+				return
+			}
+
+			var args []reflect.Value
+			if len(node.deps) == 0 {
+				args = initialArgs
+			} else {
+				mu.Lock()
+				for _, dep := range node.deps {
+					args = append(args, results[dep]...)
+				}
+				mu.Unlock()
+			}
+
+			res, err := node.task.Run(runCtx, args)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("error running task '%s': %w", name, err)
+					cancel()
+				})
+				return
+			}
+
+			mu.Lock()
+			results[name] = res
+			mu.Unlock()
+		}(name)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return g.doCleanup(Zero[T](), firstErr)
+	}
+
+	sinkResult := results[g.sink]
+	if len(sinkResult) == 0 {
+		return g.doCleanup(Zero[T](), nil)
+	}
+
+	return g.doCleanup(sinkResult[len(sinkResult)-1].Interface().(T), nil)
+}
+
+func (g *TaskGraph[T]) doCleanup(v T, err error) (T, error) {
+	if g.Cleanup != nil {
+		if e := g.Cleanup(); e != nil {
+			return v, errors.Join(e, err)
+		}
+	}
+	return v, err
+}
+
+// topoSortGraph computes a deterministic topological order of nodes using
+// Kahn's algorithm, returning CyclicDependencyErr if the graph is not a DAG.
+func topoSortGraph(nodes map[string]*graphNode) ([]string, error) {
+	inDegree := make(map[string]int, len(nodes))
+	dependents := make(map[string][]string, len(nodes))
+
+	for name := range nodes {
+		inDegree[name] = 0
+	}
+
+	for name, node := range nodes {
+		for _, dep := range node.deps {
+			inDegree[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var queue []string
+	for name, deg := range inDegree {
+		if deg == 0 {
+			queue = append(queue, name)
+		}
+	}
+	sort.Strings(queue)
+
+	order := make([]string, 0, len(nodes))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		order = append(order, name)
+
+		next := append([]string(nil), dependents[name]...)
+		sort.Strings(next)
+
+		for _, d := range next {
+			inDegree[d]--
+			if inDegree[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		return nil, CyclicDependencyErr
+	}
+
+	return order, nil
+}
+
+// findSink returns the name of the single node that no other node depends
+// on. It is an error for a non-empty graph to have zero or more than one
+// such node; zero is unreachable for an acyclic non-empty graph.
+func findSink(nodes map[string]*graphNode) (string, error) {
+	hasDependent := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		for _, dep := range node.deps {
+			hasDependent[dep] = true
+		}
+	}
+
+	var sinks []string
+	for name := range nodes {
+		if !hasDependent[name] {
+			sinks = append(sinks, name)
+		}
+	}
+
+	if len(sinks) != 1 {
+		sort.Strings(sinks)
+		return "", errors.Join(AmbiguousSinkErr, fmt.Errorf("found sink nodes: %s", strings.Join(sinks, ", ")))
+	}
+
+	return sinks[0], nil
+}
+
+// verifyGraphNodeCompatibility checks that node's Fn inputs are the
+// concatenation of its dependencies' ReturnValueTypes, in declared order.
+// Source nodes (no declared dependencies) are skipped here: their inputs
+// come from Run's params instead and are checked there.
+func verifyGraphNodeCompatibility(nodes map[string]*graphNode, node *graphNode) error {
+	if len(node.deps) == 0 {
+		return nil
+	}
+
+	var inputTypes []reflect.Type
+	for _, dep := range node.deps {
+		inputTypes = append(inputTypes, nodes[dep].task.ReturnValueTypes...)
+	}
+
+	fnType := node.task.Fn.Type()
+
+	if len(inputTypes) != fnType.NumIn() {
+		err := fmt.Errorf(invalidParamCountFmt, depNames(node.deps), len(inputTypes), node.task.Name, fnType.NumIn())
+		return errors.Join(IncompatibleFunctionSignatureErr, err)
+	}
+
+	for i, typ := range inputTypes {
+		if !typ.AssignableTo(fnType.In(i)) {
+			err := fmt.Errorf(invalidParamTypeFmt, depNames(node.deps), node.task.Name, typ, fnType.In(i))
+			return errors.Join(IncompatibleFunctionSignatureErr, err)
+		}
+	}
+
+	return nil
+}
+
+func depNames(deps []string) string {
+	if len(deps) == 0 {
+		return "(no dependencies)"
+	}
+	return strings.Join(deps, "+")
+}
@@ -0,0 +1,214 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskGraph_AddTask(t *testing.T) {
+	t.Run("must reject duplicate names", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func() int { return 2 }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		err := g.AddTask("a", nil, t2)
+		assert.ErrorIs(t, err, DuplicateTaskNameErr)
+	})
+
+	t.Run("must reject nil task", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		assert.Error(t, g.AddTask("a", nil, nil))
+	})
+}
+
+func TestTaskGraph_Build(t *testing.T) {
+	t.Run("must reject missing dependency", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		assert.NoError(t, g.AddTask("a", []string{"missing"}, t1))
+
+		err := g.Build()
+		assert.ErrorIs(t, err, UnknownDependencyErr)
+	})
+
+	t.Run("must detect cycles", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func(n int) int { return n }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(n int) int { return n }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", []string{"b"}, t1))
+		assert.NoError(t, g.AddTask("b", []string{"a"}, t2))
+
+		err := g.Build()
+		assert.ErrorIs(t, err, CyclicDependencyErr)
+	})
+
+	t.Run("must report param count mismatch", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a, b int) int { return a + b }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		assert.NoError(t, g.AddTask("b", []string{"a"}, t2))
+
+		err := g.Build()
+		assert.ErrorContains(t, err, "2")
+		assert.ErrorContains(t, err, "1")
+	})
+
+	t.Run("must report param type mismatch", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() string { return "x" }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(n int) int { return n }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		assert.NoError(t, g.AddTask("b", []string{"a"}, t2))
+
+		err := g.Build()
+		assert.ErrorContains(t, err, "string")
+		assert.ErrorContains(t, err, "int")
+	})
+
+	t.Run("must accept a dependency returning a concrete type feeding a wider interface input", func(t *testing.T) {
+		g := NewTaskGraph[string](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() Dog { return Dog{} }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a Animal) string { return a.Sound() }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		assert.NoError(t, g.AddTask("b", []string{"a"}, t2))
+
+		assert.NoError(t, g.Build())
+
+		v, err := g.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "woof", v)
+	})
+
+	t.Run("must reject multiple sinks", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func() int { return 2 }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		assert.NoError(t, g.AddTask("b", nil, t2))
+
+		err := g.Build()
+		assert.ErrorIs(t, err, AmbiguousSinkErr)
+	})
+
+	t.Run("must validate sink return type", func(t *testing.T) {
+		g := NewTaskGraph[string](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("a", nil, t1))
+		err := g.Build()
+		assert.ErrorContains(t, err, "int")
+		assert.ErrorContains(t, err, "string")
+	})
+
+	t.Run("must build an empty graph without reporting an ambiguous sink", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		assert.NoError(t, g.Build())
+	})
+}
+
+func TestTaskGraph_Run(t *testing.T) {
+	t.Run("must run dependencies concurrently and fan-in to the sink", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+
+		parse, _ := NewTask(&TaskConfig{"parse", nil, func(s string) (int, error) {
+			return strconv.Atoi(s)
+		}, nil, nil, nil})
+		double, _ := NewTask(&TaskConfig{"double", nil, func(n int) int { return n * 2 }, nil, nil, nil})
+		triple, _ := NewTask(&TaskConfig{"triple", nil, func(n int) int { return n * 3 }, nil, nil, nil})
+		sum, _ := NewTask(&TaskConfig{"sum", nil, func(a, b int) int { return a + b }, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("parse", nil, parse))
+		assert.NoError(t, g.AddTask("double", []string{"parse"}, double))
+		assert.NoError(t, g.AddTask("triple", []string{"parse"}, triple))
+		assert.NoError(t, g.AddTask("sum", []string{"double", "triple"}, sum))
+
+		v, err := g.Run(context.Background(), "10")
+		assert.NoError(t, err)
+		assert.Equal(t, 50, v)
+	})
+
+	t.Run("must cancel remaining tasks on first error", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		expectedErr := errors.New("boom")
+
+		var mu sync.Mutex
+		ran := make(map[string]bool)
+		mark := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			ran[name] = true
+		}
+
+		fail, _ := NewTask(&TaskConfig{"fail", nil, func() (int, error) {
+			mark("fail")
+			return 0, expectedErr
+		}, nil, nil, nil})
+		slow, _ := NewTask(&TaskConfig{"slow", nil, func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			mark("slow")
+			return 0, nil
+		}, nil, nil, nil})
+		next, _ := NewTask(&TaskConfig{"next", nil, func(a, b int) int {
+			mark("next")
+			return a + b
+		}, nil, nil, nil})
+
+		assert.NoError(t, g.AddTask("fail", nil, fail))
+		assert.NoError(t, g.AddTask("slow", nil, slow))
+		assert.NoError(t, g.AddTask("next", []string{"fail", "slow"}, next))
+
+		v, err := g.Run(context.Background())
+		assert.ErrorIs(t, err, expectedErr)
+		assert.Zero(t, v)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.False(t, ran["next"])
+	})
+
+	t.Run("must run Cleanup", func(t *testing.T) {
+		count := 0
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test", Cleanup: func() error {
+			count++
+			return nil
+		}})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 42 }, nil, nil, nil})
+		assert.NoError(t, g.AddTask("a", nil, t1))
+
+		v, err := g.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 42, v)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("must return zero value for an empty graph", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+
+		v, err := g.Run(context.Background())
+		assert.NoError(t, err)
+		assert.Zero(t, v)
+	})
+
+	t.Run("must recover a panic in a task and report it as an error", func(t *testing.T) {
+		g := NewTaskGraph[int](&TaskGraphConfig{Name: "test"})
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { panic("boom") }, nil, nil, nil})
+		assert.NoError(t, g.AddTask("a", nil, t1))
+
+		v, err := g.Run(context.Background())
+		assert.ErrorContains(t, err, "boom")
+		assert.Zero(t, v)
+	})
+}
@@ -0,0 +1,221 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	EmptyTaskGroupErr = errors.New("task group must have at least one task")
+	MissingMergeErr   = errors.New("task group must declare a Merge function")
+	NilGroupTaskErr   = errors.New("task group must not contain a nil task")
+)
+
+// TaskGroupConfig configures a TaskGroup.
+type TaskGroupConfig struct {
+	Name           string
+	Tasks          []*Task
+	MaxConcurrency int
+	// Merge combines the Tasks' results, indexed the same way as Tasks, into
+	// the group's own output for the next node in the chain.
+	Merge func(results [][]reflect.Value) ([]reflect.Value, error)
+	// OutputTypes declares the types Merge produces, since they cannot be
+	// derived by reflection the way a single Task's return values can.
+	OutputTypes []reflect.Type
+	Cleanup     func() error
+}
+
+// TaskGroup is a Node that runs its Tasks concurrently, all against the same
+// input values from the preceding node, and merges their results via Merge
+// into a single set of outputs for the next node. It can appear anywhere a
+// *Task can in TaskChainConfig.Tasks, except in a chain that uses
+// ParamBindings: NewTaskChain rejects TaskGroup nodes in such a chain.
+type TaskGroup struct {
+	Name           string
+	Tasks          []*Task
+	MaxConcurrency int
+	Merge          func(results [][]reflect.Value) ([]reflect.Value, error)
+	OutputTypes    []reflect.Type
+	Cleanup        func() error
+
+	inTypes []reflect.Type
+}
+
+// NewTaskGroup creates a new TaskGroup instance. Every member Task must
+// accept the same input types, since they all receive the same values.
+func NewTaskGroup(config *TaskGroupConfig) (*TaskGroup, error) {
+	if len(config.Tasks) == 0 {
+		return nil, errors.Join(EmptyTaskGroupErr, fmt.Errorf("task group '%s' has no tasks", config.Name))
+	}
+
+	if config.Merge == nil {
+		return nil, errors.Join(MissingMergeErr, fmt.Errorf("task group '%s' has no Merge function", config.Name))
+	}
+
+	for i, task := range config.Tasks {
+		if task == nil {
+			err := fmt.Errorf("task group '%s' has a nil task at index %d", config.Name, i)
+			return nil, errors.Join(NilGroupTaskErr, err)
+		}
+	}
+
+	inTypes := config.Tasks[0].inputTypes()
+	for _, task := range config.Tasks[1:] {
+		if !sameTypes(inTypes, task.inputTypes()) {
+			err := fmt.Errorf("task '%s' accepts different input types than task '%s', but all tasks in group '%s' must accept the same inputs", task.Name, config.Tasks[0].Name, config.Name)
+			return nil, errors.Join(IncompatibleFunctionSignatureErr, err)
+		}
+	}
+
+	return &TaskGroup{
+		Name:           config.Name,
+		Tasks:          config.Tasks,
+		MaxConcurrency: config.MaxConcurrency,
+		Merge:          config.Merge,
+		OutputTypes:    config.OutputTypes,
+		Cleanup:        config.Cleanup,
+		inTypes:        inTypes,
+	}, nil
+}
+
+// Run executes every member Task concurrently against params, up to
+// MaxConcurrency at a time (unbounded when MaxConcurrency <= 0). The first
+// member error cancels the ctx derived for the remaining members and is
+// joined with every other member's error via errors.Join. Each member's own
+// Cleanup still runs, via Task.Run, or directly for a member skipped because
+// the ctx was cancelled before it started; a member whose Fn panics does not
+// run its Cleanup. Merge is only invoked once every member has succeeded.
+func (g *TaskGroup) Run(ctx context.Context, params []reflect.Value) ([]reflect.Value, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxConcurrency := g.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(g.Tasks)
+	}
+	sem := make(chan struct{}, maxConcurrency)
+
+	results := make([][]reflect.Value, len(g.Tasks))
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		groupErr error
+	)
+
+	for i, task := range g.Tasks {
+		wg.Add(1)
+		go func(i int, task *Task) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mu.Lock()
+					groupErr = errors.Join(groupErr, fmt.Errorf("panic running group task '%s': %v", task.Name, r))
+					mu.Unlock()
+					cancel()
+				}
+			}()
+
+			// cleanupSkipped runs the Cleanup of a member that never reaches
+			// Task.Run because runCtx was cancelled first.
+			cleanupSkipped := func() {
+				if _, cuErr := task.doCleanup(nil, nil); cuErr != nil {
+					mu.Lock()
+					groupErr = errors.Join(groupErr, fmt.Errorf("error cleaning up group task '%s': %w", task.Name, cuErr))
+					mu.Unlock()
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-runCtx.Done():
+				cleanupSkipped()
+				return
+			}
+			defer func() { <-sem }()
+
+			if runCtx.Err() != nil {
+				cleanupSkipped()
+				return
+			}
+
+			res, err := task.Run(runCtx, params)
+			if err != nil {
+				mu.Lock()
+				groupErr = errors.Join(groupErr, fmt.Errorf("error running group task '%s': %w", task.Name, err))
+				mu.Unlock()
+				cancel()
+				return
+			}
+
+			mu.Lock()
+			results[i] = res
+			mu.Unlock()
+		}(i, task)
+	}
+
+	wg.Wait()
+
+	if groupErr != nil {
+		return g.doCleanup(nil, groupErr)
+	}
+
+	merged, err := g.Merge(results)
+	if err != nil {
+		return g.doCleanup(nil, fmt.Errorf("error merging task group '%s': %w", g.Name, err))
+	}
+
+	if err := g.verifyMergedTypes(merged); err != nil {
+		return g.doCleanup(nil, err)
+	}
+
+	return g.doCleanup(merged, nil)
+}
+
+// verifyMergedTypes guards against Merge returning values that don't match
+// the OutputTypes declared at construction time, since Merge is a plain func
+// whose real return types can't be checked by reflection the way a *Task's
+// Fn can.
+func (g *TaskGroup) verifyMergedTypes(merged []reflect.Value) error {
+	if len(merged) != len(g.OutputTypes) {
+		return fmt.Errorf("task group '%s': Merge returned %d value(s) but OutputTypes declares %d", g.Name, len(merged), len(g.OutputTypes))
+	}
+	for i, v := range merged {
+		if !v.Type().AssignableTo(g.OutputTypes[i]) {
+			return fmt.Errorf("task group '%s': Merge returned %s at index %d but OutputTypes declares %s", g.Name, v.Type(), i, g.OutputTypes[i])
+		}
+	}
+	return nil
+}
+
+func (g *TaskGroup) doCleanup(v []reflect.Value, err error) ([]reflect.Value, error) {
+	if g.Cleanup != nil {
+		if cuErr := g.Cleanup(); cuErr != nil {
+			return v, errors.Join(cuErr, err)
+		}
+	}
+	return v, err
+}
+
+func (g *TaskGroup) nodeName() string            { return g.Name }
+func (g *TaskGroup) inputTypes() []reflect.Type  { return g.inTypes }
+func (g *TaskGroup) outputTypes() []reflect.Type { return g.OutputTypes }
+
+func sameTypes(a, b []reflect.Type) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
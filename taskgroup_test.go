@@ -0,0 +1,226 @@
+package grace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sumMerge(results [][]reflect.Value) ([]reflect.Value, error) {
+	total := 0
+	for _, r := range results {
+		total += int(r[0].Int())
+	}
+	return []reflect.Value{reflect.ValueOf(total)}, nil
+}
+
+func TestNewTaskGroup(t *testing.T) {
+	t.Run("must reject empty Tasks", func(t *testing.T) {
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Merge: sumMerge})
+		assert.ErrorIs(t, err, EmptyTaskGroupErr)
+		assert.Nil(t, g)
+	})
+
+	t.Run("must reject missing Merge", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Tasks: []*Task{t1}})
+		assert.ErrorIs(t, err, MissingMergeErr)
+		assert.Nil(t, g)
+	})
+
+	t.Run("must reject members with different input types", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func(a int) int { return a }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, func(a string) int { return 0 }, nil, nil, nil})
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Tasks: []*Task{t1, t2}, Merge: sumMerge})
+		assert.ErrorIs(t, err, IncompatibleFunctionSignatureErr)
+		assert.Nil(t, g)
+	})
+
+	t.Run("must reject a nil task", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+		t2, _ := NewTask(&TaskConfig{"t2", nil, []string{"not a func"}, nil, nil, nil}) // fails, t2 is nil
+
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Tasks: []*Task{t1, t2}, Merge: sumMerge})
+		assert.ErrorIs(t, err, NilGroupTaskErr)
+		assert.Nil(t, g)
+	})
+}
+
+func TestTaskGroup_Run(t *testing.T) {
+	t.Run("must run every member concurrently and merge results", func(t *testing.T) {
+		double, _ := NewTask(&TaskConfig{"double", nil, func(n int) int { return n * 2 }, nil, nil, nil})
+		triple, _ := NewTask(&TaskConfig{"triple", nil, func(n int) int { return n * 3 }, nil, nil, nil})
+
+		g, err := NewTaskGroup(&TaskGroupConfig{
+			Name:        "g",
+			Tasks:       []*Task{double, triple},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+		assert.NoError(t, err)
+
+		v, err := g.Run(context.Background(), []reflect.Value{reflect.ValueOf(10)})
+		assert.NoError(t, err)
+		assert.Equal(t, 50, int(v[0].Int()))
+	})
+
+	t.Run("must cancel siblings and join errors on first member failure", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+
+		var mu sync.Mutex
+		ran := make(map[string]bool)
+		mark := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			ran[name] = true
+		}
+
+		fail, _ := NewTask(&TaskConfig{"fail", nil, func() (int, error) {
+			mark("fail")
+			return 0, expectedErr
+		}, nil, nil, nil})
+		slow, _ := NewTask(&TaskConfig{"slow", nil, func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			mark("slow")
+			return 0, nil
+		}, nil, nil, nil})
+
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Tasks: []*Task{fail, slow}, Merge: sumMerge})
+		assert.NoError(t, err)
+
+		_, err = g.Run(context.Background(), nil)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("must recover a panic in a member task and report it as an error", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { panic("boom") }, nil, nil, nil})
+
+		g, err := NewTaskGroup(&TaskGroupConfig{Name: "g", Tasks: []*Task{t1}, Merge: sumMerge})
+		assert.NoError(t, err)
+
+		_, err = g.Run(context.Background(), nil)
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("must reject a Merge result that doesn't match declared OutputTypes", func(t *testing.T) {
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+
+		g, err := NewTaskGroup(&TaskGroupConfig{
+			Name:  "g",
+			Tasks: []*Task{t1},
+			Merge: func(results [][]reflect.Value) ([]reflect.Value, error) {
+				return []reflect.Value{reflect.ValueOf("not an int")}, nil
+			},
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+		assert.NoError(t, err)
+
+		_, err = g.Run(context.Background(), nil)
+		assert.ErrorContains(t, err, "string")
+		assert.ErrorContains(t, err, "int")
+	})
+
+	t.Run("must run group Cleanup", func(t *testing.T) {
+		count := 0
+		t1, _ := NewTask(&TaskConfig{"t1", nil, func() int { return 1 }, nil, nil, nil})
+
+		g, err := NewTaskGroup(&TaskGroupConfig{
+			Name:        "g",
+			Tasks:       []*Task{t1},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+			Cleanup: func() error {
+				count++
+				return nil
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = g.Run(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestTaskChain_TaskGroupNode(t *testing.T) {
+	t.Run("must run a TaskGroup as a chain node between two Tasks", func(t *testing.T) {
+		start, _ := NewTask(&TaskConfig{"start", nil, func(s string) int {
+			n := 0
+			for _, c := range s {
+				n = n*10 + int(c-'0')
+			}
+			return n
+		}, nil, nil, nil})
+		double, _ := NewTask(&TaskConfig{"double", nil, func(n int) int { return n * 2 }, nil, nil, nil})
+		triple, _ := NewTask(&TaskConfig{"triple", nil, func(n int) int { return n * 3 }, nil, nil, nil})
+		group, _ := NewTaskGroup(&TaskGroupConfig{
+			Name:        "fanout",
+			Tasks:       []*Task{double, triple},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+		finish, _ := NewTask(&TaskConfig{"finish", nil, func(n int) int { return n + 1 }, nil, nil, nil})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{start, group, finish}, nil})
+		assert.NoError(t, err)
+		assert.NotNil(t, chain)
+
+		v, err := chain.Run(context.Background(), "10")
+		assert.NoError(t, err)
+		assert.Equal(t, 51, v)
+	})
+
+	t.Run("must report incompatible types between a Task and a TaskGroup node", func(t *testing.T) {
+		start, _ := NewTask(&TaskConfig{"start", nil, func() string { return "x" }, nil, nil, nil})
+		member, _ := NewTask(&TaskConfig{"member", nil, func(n int) int { return n }, nil, nil, nil})
+		group, _ := NewTaskGroup(&TaskGroupConfig{
+			Name:        "g",
+			Tasks:       []*Task{member},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{start, group}, nil})
+		assert.ErrorIs(t, err, IncompatibleFunctionSignatureErr)
+		assert.Nil(t, chain)
+	})
+
+	t.Run("must run a TaskGroup as the last node of a chain", func(t *testing.T) {
+		double, _ := NewTask(&TaskConfig{"double", nil, func(n int) int { return n * 2 }, nil, nil, nil})
+		triple, _ := NewTask(&TaskConfig{"triple", nil, func(n int) int { return n * 3 }, nil, nil, nil})
+		group, _ := NewTaskGroup(&TaskGroupConfig{
+			Name:        "fanout",
+			Tasks:       []*Task{double, triple},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+
+		chain, err := NewTaskChain[int](&TaskChainConfig{"test", nil, []Node{group}, nil})
+		assert.NoError(t, err)
+		assert.NotNil(t, chain)
+
+		v, err := chain.Run(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, v)
+	})
+
+	t.Run("must reject a TaskGroup as the last node when its OutputTypes mismatch T", func(t *testing.T) {
+		double, _ := NewTask(&TaskConfig{"double", nil, func(n int) int { return n * 2 }, nil, nil, nil})
+		group, _ := NewTaskGroup(&TaskGroupConfig{
+			Name:        "fanout",
+			Tasks:       []*Task{double},
+			Merge:       sumMerge,
+			OutputTypes: []reflect.Type{reflect.TypeOf(0)},
+		})
+
+		chain, err := NewTaskChain[string](&TaskChainConfig{"test", nil, []Node{group}, nil})
+		assert.ErrorContains(t, err, "int")
+		assert.ErrorContains(t, err, "string")
+		assert.Nil(t, chain)
+	})
+}
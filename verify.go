@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 const (
@@ -14,15 +15,21 @@ const (
 var NotFuncErr = errors.New("Fn must be a function")
 var IncompatibleFunctionSignatureErr = errors.New("incompatible task function signatures")
 
-func verifyTaskCompatibility(output, input *Task) error {
-	if len(output.ReturnValueTypes) != input.Fn.Type().NumIn() {
-		err := fmt.Errorf(invalidParamCountFmt, output.Name, len(output.ReturnValueTypes), input.Name, input.Fn.Type().NumIn())
+// verifyNodeCompatibility checks that curr's inputs are the prev node's
+// outputs, position by position. prev and curr may each be a *Task or a
+// *TaskGroup.
+func verifyNodeCompatibility(prev, curr Node) error {
+	prevOut := prev.outputTypes()
+	currIn := curr.inputTypes()
+
+	if len(prevOut) != len(currIn) {
+		err := fmt.Errorf(invalidParamCountFmt, prev.nodeName(), len(prevOut), curr.nodeName(), len(currIn))
 		return errors.Join(IncompatibleFunctionSignatureErr, err)
 	}
 
-	for i, typ := range output.ReturnValueTypes {
-		if !input.Fn.Type().In(i).AssignableTo(typ) {
-			err := fmt.Errorf(invalidParamTypeFmt, output.Name, input.Name, output.ReturnValueTypes[i], input.Fn.Type().In(i))
+	for i, typ := range prevOut {
+		if !typ.AssignableTo(currIn[i]) {
+			err := fmt.Errorf(invalidParamTypeFmt, prev.nodeName(), curr.nodeName(), typ, currIn[i])
 			return errors.Join(IncompatibleFunctionSignatureErr, err)
 		}
 	}
@@ -46,6 +53,46 @@ func verifyReturnType[T any](last *Task) error {
 	return nil
 }
 
+// verifyLastNodeReturnType checks the last node of a TaskChain against T. A
+// *Task keeps using verifyReturnType's existing, Fn-based behavior; any other
+// Node (namely *TaskGroup) is checked against its declared outputTypes.
+func verifyLastNodeReturnType[T any](node Node) error {
+	if t, ok := node.(*Task); ok {
+		return verifyReturnType[T](t)
+	}
+
+	outs := node.outputTypes()
+	if len(outs) == 0 {
+		return nil
+	}
+
+	retTyp := reflect.TypeFor[T]()
+	outTyp := outs[len(outs)-1]
+
+	if !outTyp.AssignableTo(retTyp) {
+		return fmt.Errorf("return type %s is not compatible to the last output type: %s", outTyp, retTyp)
+	}
+
+	return nil
+}
+
+func verifyInitialNodeParams(node Node, params []interface{}) error {
+	expected := node.inputTypes()
+
+	if len(params) != len(expected) {
+		return fmt.Errorf("invalid input params: expected %v params but got %v", len(expected), len(params))
+	}
+
+	for i, p := range params {
+		actualType := reflect.TypeOf(p)
+		if !actualType.AssignableTo(expected[i]) {
+			return fmt.Errorf("invalid input params: expected %s at %d but got %s", expected[i], i+1, actualType)
+		}
+	}
+
+	return nil
+}
+
 func verifyInitialParams(firstFn reflect.Type, params []interface{}) error {
 	paramSize := len(params)
 	expectedSize := firstFn.NumIn()
@@ -70,3 +117,101 @@ func isFunc(fn reflect.Type) error {
 	}
 	return nil
 }
+
+var MissingParamBindingErr = errors.New("missing or invalid ParamBindings entry")
+
+// verifyTaskBindings validates named-results mode for a TaskChain: tasks[0]
+// may not declare ParamBindings (it has nothing to bind to; its inputs come
+// from TaskChain.Run's params instead), and every other task must declare
+// exactly one ParamBindings entry per Fn input, each referencing, by
+// "taskName.resultName", one of an earlier task's ResultNames with an
+// assignable type.
+func verifyTaskBindings(nodes []Node) error {
+	tasks := make([]*Task, len(nodes))
+	for i, n := range nodes {
+		t, ok := n.(*Task)
+		if !ok {
+			err := fmt.Errorf("node %d ('%s'): named-results mode does not support TaskGroup nodes", i, n.nodeName())
+			return errors.Join(MissingParamBindingErr, err)
+		}
+		tasks[i] = t
+	}
+
+	taskIndex := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		taskIndex[t.Name] = i
+	}
+
+	for i, t := range tasks {
+		if i == 0 {
+			if len(t.ParamBindings) > 0 {
+				err := fmt.Errorf("task '%s' declares ParamBindings but has no preceding task to bind to", t.Name)
+				return errors.Join(MissingParamBindingErr, err)
+			}
+			continue
+		}
+
+		if len(t.ParamBindings) != t.Fn.Type().NumIn() {
+			err := fmt.Errorf("task '%s' must declare one ParamBindings entry per input (%d), but declares %d", t.Name, t.Fn.Type().NumIn(), len(t.ParamBindings))
+			return errors.Join(MissingParamBindingErr, err)
+		}
+
+		for paramIdx, binding := range t.ParamBindings {
+			producerName, resultName, ok := strings.Cut(binding, ".")
+			if !ok {
+				err := fmt.Errorf("task '%s': ParamBindings entry '%s' is not in 'taskName.resultName' form", t.Name, binding)
+				return errors.Join(MissingParamBindingErr, err)
+			}
+
+			producerIdx, ok := taskIndex[producerName]
+			if !ok || producerIdx >= i {
+				err := fmt.Errorf("task '%s': ParamBindings entry '%s' does not reference a task that runs before it", t.Name, binding)
+				return errors.Join(MissingParamBindingErr, err)
+			}
+
+			producer := tasks[producerIdx]
+			resultIdx := -1
+			for ri, name := range producer.ResultNames {
+				if name == resultName {
+					resultIdx = ri
+					break
+				}
+			}
+			if resultIdx == -1 {
+				err := fmt.Errorf("task '%s': ParamBindings entry '%s' references unknown result '%s' of task '%s'", t.Name, binding, resultName, producerName)
+				return errors.Join(MissingParamBindingErr, err)
+			}
+
+			producedType := producer.ReturnValueTypes[resultIdx]
+			inputType := t.Fn.Type().In(paramIdx)
+			if !producedType.AssignableTo(inputType) {
+				err := fmt.Errorf(invalidParamTypeFmt, binding, t.Name, producedType, inputType)
+				return errors.Join(IncompatibleFunctionSignatureErr, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var taskChainStatusType = reflect.TypeFor[TaskChainStatus]()
+
+// verifyFinallyTask checks that a Finally task's Fn either takes no inputs,
+// or a single input that a TaskChainStatus is assignable to.
+func verifyFinallyTask(task *Task) error {
+	fnType := task.Fn.Type()
+
+	switch fnType.NumIn() {
+	case 0:
+		return nil
+	case 1:
+		if !taskChainStatusType.AssignableTo(fnType.In(0)) {
+			err := fmt.Errorf("finally task '%s' takes %s, which %s cannot be assigned to", task.Name, fnType.In(0), taskChainStatusType)
+			return errors.Join(IncompatibleFunctionSignatureErr, err)
+		}
+		return nil
+	default:
+		err := fmt.Errorf("finally task '%s' must take zero inputs or a single %s input, but takes %d inputs", task.Name, taskChainStatusType, fnType.NumIn())
+		return errors.Join(IncompatibleFunctionSignatureErr, err)
+	}
+}